@@ -0,0 +1,125 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AssignMetadataParameters defines the value to assign.
+type AssignMetadataParameters struct {
+	Assign runtime.RawExtension `json:"assign,omitempty"`
+}
+
+// AssignMetadataSpec defines the desired state of AssignMetadata.
+type AssignMetadataSpec struct {
+	ApplyTo []ApplyTo `json:"applyTo,omitempty"`
+	Match   Match     `json:"match,omitempty"`
+
+	// Location must be of the form metadata.labels.<key> or
+	// metadata.annotations.<key>.
+	Location   string                   `json:"location,omitempty"`
+	Parameters AssignMetadataParameters `json:"parameters,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AssignMetadata is the Schema for the assignmetadata API.
+type AssignMetadata struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AssignMetadataSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AssignMetadataList contains a list of AssignMetadata.
+type AssignMetadataList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AssignMetadata `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AssignMetadata{}, &AssignMetadataList{})
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssignMetadata) DeepCopyInto(out *AssignMetadata) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new AssignMetadata.
+func (in *AssignMetadata) DeepCopy() *AssignMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(AssignMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AssignMetadata) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssignMetadataSpec) DeepCopyInto(out *AssignMetadataSpec) {
+	*out = *in
+	if in.ApplyTo != nil {
+		out.ApplyTo = make([]ApplyTo, len(in.ApplyTo))
+		for i := range in.ApplyTo {
+			in.ApplyTo[i].DeepCopyInto(&out.ApplyTo[i])
+		}
+	}
+	in.Match.DeepCopyInto(&out.Match)
+	in.Parameters.Assign.DeepCopyInto(&out.Parameters.Assign)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new AssignMetadataSpec.
+func (in *AssignMetadataSpec) DeepCopy() *AssignMetadataSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AssignMetadataSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssignMetadataList) DeepCopyInto(out *AssignMetadataList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]AssignMetadata, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new AssignMetadataList.
+func (in *AssignMetadataList) DeepCopy() *AssignMetadataList {
+	if in == nil {
+		return nil
+	}
+	out := new(AssignMetadataList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AssignMetadataList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RemoveSpec defines the desired state of Remove.
+type RemoveSpec struct {
+	ApplyTo []ApplyTo `json:"applyTo,omitempty"`
+	Match   Match     `json:"match,omitempty"`
+
+	// Location describes the path of the field or list entry to remove,
+	// e.g. "spec.containers[name: foo].securityContext.privileged" or
+	// "spec.imagePullSecrets[name: my-secret]".
+	Location string `json:"location,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Remove is the Schema for the removes API.
+type Remove struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RemoveSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoveList contains a list of Remove.
+type RemoveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Remove `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Remove{}, &RemoveList{})
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Remove) DeepCopyInto(out *Remove) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new Remove.
+func (in *Remove) DeepCopy() *Remove {
+	if in == nil {
+		return nil
+	}
+	out := new(Remove)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Remove) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveSpec) DeepCopyInto(out *RemoveSpec) {
+	*out = *in
+	if in.ApplyTo != nil {
+		out.ApplyTo = make([]ApplyTo, len(in.ApplyTo))
+		for i := range in.ApplyTo {
+			in.ApplyTo[i].DeepCopyInto(&out.ApplyTo[i])
+		}
+	}
+	in.Match.DeepCopyInto(&out.Match)
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RemoveSpec.
+func (in *RemoveSpec) DeepCopy() *RemoveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveList) DeepCopyInto(out *RemoveList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]Remove, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RemoveList.
+func (in *RemoveList) DeepCopy() *RemoveList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoveList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is a deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoveList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
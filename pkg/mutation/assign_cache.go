@@ -0,0 +1,121 @@
+package mutation
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// matcherFunc is a compiled matcher for an Assign's spec.match, closing
+// over the match criteria so repeated Matches calls don't re-walk the
+// match spec on every admission request.
+type matcherFunc func(obj runtime.Object, ns *corev1.Namespace) (bool, error)
+
+// assignCacheKey identifies an Assign by identity and generation: UID
+// pins the object, and resourceVersion invalidates the entry whenever
+// the spec (and therefore the derived path/bindings/matcher) may have
+// changed.
+type assignCacheKey struct {
+	uid             string
+	resourceVersion string
+}
+
+type assignCacheEntry struct {
+	key      assignCacheKey
+	path     *parser.Path
+	bindings []SchemaBinding
+	matcher  matcherFunc
+}
+
+// AssignCache memoizes the path, schema bindings, and compiled matcher
+// derived from an Assign spec, so the admission hot path doesn't re-parse
+// and re-allocate them on every request. Implementations must be safe
+// for concurrent use.
+type AssignCache interface {
+	// Get returns the cached path/bindings/matcher for uid+resourceVersion,
+	// if present.
+	Get(uid, resourceVersion string) (path *parser.Path, bindings []SchemaBinding, matcher matcherFunc, ok bool)
+	// Put stores the derived path/bindings/matcher for uid+resourceVersion,
+	// evicting the least recently used entry if the cache is full.
+	Put(uid, resourceVersion string, path *parser.Path, bindings []SchemaBinding, matcher matcherFunc)
+}
+
+// lruAssignCache is the production AssignCache: an LRU bounded by entry
+// count, guarded by a sync.RWMutex. Get needs the write lock too: bumping
+// an entry to the front of the LRU list on a hit mutates shared state, so
+// it and the subsequent read of elem.Value must happen under the same
+// critical section as the lookup, not after releasing it.
+type lruAssignCache struct {
+	mu       sync.RWMutex
+	capacity int
+	ll       *list.List
+	items    map[assignCacheKey]*list.Element
+}
+
+// NewAssignCache returns an AssignCache that retains at most capacity
+// entries, evicting the least recently used one once full.
+func NewAssignCache(capacity int) AssignCache {
+	return &lruAssignCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[assignCacheKey]*list.Element),
+	}
+}
+
+func (c *lruAssignCache) Get(uid, resourceVersion string) (*parser.Path, []SchemaBinding, matcherFunc, bool) {
+	key := assignCacheKey{uid: uid, resourceVersion: resourceVersion}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, nil, nil, false
+	}
+	c.ll.MoveToFront(elem)
+
+	entry := elem.Value.(*assignCacheEntry)
+	return entry.path, entry.bindings, entry.matcher, true
+}
+
+func (c *lruAssignCache) Put(uid, resourceVersion string, path *parser.Path, bindings []SchemaBinding, matcher matcherFunc) {
+	key := assignCacheKey{uid: uid, resourceVersion: resourceVersion}
+	entry := &assignCacheEntry{key: key, path: path, bindings: bindings, matcher: matcher}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value = entry
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(entry)
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*assignCacheEntry).key)
+		}
+	}
+}
+
+// noopAssignCache never retains anything; it lets tests exercise
+// MutatorForAssign's parse/validate path directly without depending on
+// cache behavior.
+type noopAssignCache struct{}
+
+// NewNoopAssignCache returns an AssignCache that always misses.
+func NewNoopAssignCache() AssignCache {
+	return noopAssignCache{}
+}
+
+func (noopAssignCache) Get(string, string) (*parser.Path, []SchemaBinding, matcherFunc, bool) {
+	return nil, nil, nil, false
+}
+
+func (noopAssignCache) Put(string, string, *parser.Path, []SchemaBinding, matcherFunc) {}
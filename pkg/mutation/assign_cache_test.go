@@ -0,0 +1,88 @@
+package mutation
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+)
+
+func TestLRUAssignCache_GetPutRoundTrip(t *testing.T) {
+	cache := NewAssignCache(10)
+	path := &parser.Path{Nodes: []parser.Node{&parser.Object{Reference: "spec"}}}
+	bindings := []SchemaBinding{{Kinds: []string{"Pod"}}}
+
+	if _, _, _, ok := cache.Get("uid-1", "1"); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+
+	var m matcherFunc
+	cache.Put("uid-1", "1", path, bindings, m)
+
+	gotPath, gotBindings, _, ok := cache.Get("uid-1", "1")
+	if !ok {
+		t.Fatal("expected a hit after Put")
+	}
+	if gotPath != path {
+		t.Error("expected Get to return the exact path pointer that was Put")
+	}
+	if len(gotBindings) != 1 || gotBindings[0].Kinds[0] != "Pod" {
+		t.Errorf("unexpected bindings: %+v", gotBindings)
+	}
+
+	if _, _, _, ok := cache.Get("uid-1", "2"); ok {
+		t.Error("expected a miss for a different resourceVersion of the same object")
+	}
+}
+
+func TestLRUAssignCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewAssignCache(2)
+	path := &parser.Path{}
+
+	cache.Put("uid-1", "1", path, nil, nil)
+	cache.Put("uid-2", "1", path, nil, nil)
+
+	// touch uid-1 so uid-2 becomes the least recently used
+	if _, _, _, ok := cache.Get("uid-1", "1"); !ok {
+		t.Fatal("expected uid-1 to be cached")
+	}
+
+	cache.Put("uid-3", "1", path, nil, nil)
+
+	if _, _, _, ok := cache.Get("uid-2", "1"); ok {
+		t.Error("expected uid-2 to have been evicted as least recently used")
+	}
+	if _, _, _, ok := cache.Get("uid-1", "1"); !ok {
+		t.Error("expected uid-1 to survive eviction")
+	}
+	if _, _, _, ok := cache.Get("uid-3", "1"); !ok {
+		t.Error("expected uid-3 to be cached")
+	}
+}
+
+func TestLRUAssignCache_ConcurrentGetPut(t *testing.T) {
+	cache := NewAssignCache(50)
+	path := &parser.Path{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			uid := fmt.Sprintf("uid-%d", i%20)
+			cache.Put(uid, "1", path, nil, nil)
+			cache.Get(uid, "1")
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestNoopAssignCache_AlwaysMisses(t *testing.T) {
+	cache := NewNoopAssignCache()
+	cache.Put("uid-1", "1", &parser.Path{}, nil, nil)
+
+	if _, _, _, ok := cache.Get("uid-1", "1"); ok {
+		t.Error("expected the no-op cache to never return a hit")
+	}
+}
@@ -17,17 +17,19 @@ import (
 // AssignMutator is a mutator object built out of a
 // Assign instance.
 type AssignMutator struct {
-	id       ID
-	assign   *mutationsv1alpha1.Assign
-	path     *parser.Path
-	bindings []SchemaBinding
+	id          ID
+	assign      *mutationsv1alpha1.Assign
+	path        *parser.Path
+	bindings    []SchemaBinding
+	patchSource PatchMetaSource
+	matcher     matcherFunc
 }
 
 // AssignMutator implements mutatorWithSchema
 var _ MutatorWithSchema = &AssignMutator{}
 
 func (m *AssignMutator) Matches(obj runtime.Object, ns *corev1.Namespace) bool {
-	matches, err := Matches(m.assign.Spec.Match, obj, ns)
+	matches, err := m.matcher(obj, ns)
 	if err != nil {
 		log.Error(err, "AssignMutator.Matches failed", "assign", m.assign.Name)
 		return false
@@ -35,7 +37,18 @@ func (m *AssignMutator) Matches(obj runtime.Object, ns *corev1.Namespace) bool {
 	return matches
 }
 
+// Mutate applies the assign spec to obj. When obj's GVK is a built-in
+// type with a known strategic-merge-patch schema, it is mutated through
+// that backend so list merge keys are respected; otherwise it falls back
+// to the path-walking backend used for CRDs. A globbed list node (e.g.
+// containers[name: *]) has no single merge key to target, so it always
+// uses the path-walking backend regardless of patchSource.
 func (m *AssignMutator) Mutate(obj *unstructured.Unstructured) error {
+	if m.patchSource != nil && !pathHasGlob(m.path.Nodes) {
+		if lookup, ok := m.patchSource.LookupPatchMeta(obj.GroupVersionKind()); ok {
+			return mutateWithStrategicMergePatch(m, obj, lookup)
+		}
+	}
 	return Mutate(m, obj)
 }
 func (m *AssignMutator) ID() ID {
@@ -85,31 +98,71 @@ func (m *AssignMutator) DeepCopy() Mutator {
 		path: &parser.Path{
 			Nodes: make([]parser.Node, len(m.path.Nodes)),
 		},
-		bindings: make([]SchemaBinding, len(m.bindings)),
+		bindings:    make([]SchemaBinding, len(m.bindings)),
+		patchSource: m.patchSource,
+		matcher:     m.matcher,
 	}
 	copy(res.path.Nodes, m.path.Nodes)
 	copy(res.bindings, m.bindings)
 	return res
 }
 
-// MutatorForAssign returns an AssignMutator built from
-// the given assign instance.
-func MutatorForAssign(assign *mutationsv1alpha1.Assign) (*AssignMutator, error) {
+// MutatorForAssign returns an AssignMutator built from the given assign
+// instance. When validator is non-nil, the assign's value is checked
+// against the OpenAPI schema of every GVK it applies to, and a
+// schema-incompatible value is rejected here rather than silently
+// no-oping at mutation time. When patchSource is non-nil, it is consulted
+// at mutation time to pick the strategic-merge-patch backend over the
+// path-walking one for GVKs it knows about. When cache is non-nil, the
+// parsed path, derived bindings, and compiled matcher are memoized by the
+// assign object's UID and resourceVersion, so a repeat admission request
+// for an unchanged Assign skips re-parsing and re-validating entirely.
+func MutatorForAssign(assign *mutationsv1alpha1.Assign, validator SchemaValidator, patchSource PatchMetaSource, cache AssignCache) (*AssignMutator, error) {
 	id, err := MakeID(assign)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to retrieve id for assign type")
 	}
 
-	path, err := parser.Parse(assign.Spec.Location)
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to parse the location specified")
+	var path *parser.Path
+	var bindings []SchemaBinding
+	var matcher matcherFunc
+	var cached bool
+	if cache != nil {
+		path, bindings, matcher, cached = cache.Get(string(assign.GetUID()), assign.GetResourceVersion())
+	}
+
+	if !cached {
+		path, err = parser.Parse(assign.Spec.Location)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to parse the location specified")
+		}
+		bindings = applyToToBindings(assign.Spec.ApplyTo)
+		matcher = func(obj runtime.Object, ns *corev1.Namespace) (bool, error) {
+			return Matches(assign.Spec.Match, obj, ns)
+		}
+
+		if validator != nil {
+			value, err := unmarshalValue(assign.Spec.Parameters.Assign.Raw)
+			if err != nil {
+				return nil, errors.Wrap(err, "Failed to unmarshal the assign value")
+			}
+			if err := validator.ValidateAssign(bindings, path, value); err != nil {
+				return nil, errors.Wrap(err, "assign value does not match target schema")
+			}
+		}
+
+		if cache != nil {
+			cache.Put(string(assign.GetUID()), assign.GetResourceVersion(), path, bindings, matcher)
+		}
 	}
 
 	return &AssignMutator{
-		id:       id,
-		assign:   assign.DeepCopy(),
-		bindings: applyToToBindings(assign.Spec.ApplyTo),
-		path:     path,
+		id:          id,
+		assign:      assign.DeepCopy(),
+		bindings:    bindings,
+		path:        path,
+		patchSource: patchSource,
+		matcher:     matcher,
 	}, nil
 }
 
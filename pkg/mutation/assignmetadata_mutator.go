@@ -0,0 +1,202 @@
+package mutation
+
+import (
+	"github.com/google/go-cmp/cmp"
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AssignMetadataMutator is a mutator object built out of an
+// AssignMetadata instance.
+type AssignMetadataMutator struct {
+	id             ID
+	assignMetadata *mutationsv1alpha1.AssignMetadata
+	path           *parser.Path
+	bindings       []SchemaBinding
+}
+
+// AssignMetadataMutator implements mutatorWithSchema
+var _ MutatorWithSchema = &AssignMetadataMutator{}
+
+func (m *AssignMetadataMutator) Matches(obj runtime.Object, ns *corev1.Namespace) bool {
+	matches, err := Matches(m.assignMetadata.Spec.Match, obj, ns)
+	if err != nil {
+		log.Error(err, "AssignMetadataMutator.Matches failed", "assignMetadata", m.assignMetadata.Name)
+		return false
+	}
+	return matches
+}
+
+// Mutate writes the assigned value into the label or annotation named by
+// the mutator's location, but only when that key is not already set.
+// This create-only behavior keeps AssignMetadata from clobbering labels
+// and annotations the user (or another mutator) already set.
+func (m *AssignMetadataMutator) Mutate(obj *unstructured.Unstructured) error {
+	root, section, key, err := metadataLocationParts(m.path)
+	if err != nil {
+		return err
+	}
+
+	strValue, err := stringValue(m.assignMetadata)
+	if err != nil {
+		return err
+	}
+
+	fields, _, err := unstructured.NestedStringMap(obj.Object, root, section)
+	if err != nil {
+		return err
+	}
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	if _, exists := fields[key]; exists {
+		return nil
+	}
+
+	fields[key] = strValue
+	return unstructured.SetNestedStringMap(obj.Object, fields, root, section)
+}
+
+func (m *AssignMetadataMutator) ID() ID {
+	return m.id
+}
+
+func (m *AssignMetadataMutator) SchemaBindings() []SchemaBinding {
+	return m.bindings
+}
+
+func (m *AssignMetadataMutator) Value() (interface{}, error) {
+	return unmarshalValue(m.assignMetadata.Spec.Parameters.Assign.Raw)
+}
+
+func (m *AssignMetadataMutator) HasDiff(mutator Mutator) bool {
+	toCheck, ok := mutator.(*AssignMetadataMutator)
+	if !ok { // different types, different
+		return true
+	}
+
+	if !cmp.Equal(toCheck.id, m.id) {
+		return true
+	}
+	if !cmp.Equal(toCheck.path, m.path) {
+		return true
+	}
+	if !cmp.Equal(toCheck.bindings, m.bindings) {
+		return true
+	}
+
+	// any difference in spec may be enough
+	if !cmp.Equal(toCheck.assignMetadata.Spec, m.assignMetadata.Spec) {
+		return true
+	}
+
+	return false
+}
+
+func (m *AssignMetadataMutator) Path() *parser.Path {
+	return m.path
+}
+
+func (m *AssignMetadataMutator) DeepCopy() Mutator {
+	res := &AssignMetadataMutator{
+		id:             m.id,
+		assignMetadata: m.assignMetadata.DeepCopy(),
+		path: &parser.Path{
+			Nodes: make([]parser.Node, len(m.path.Nodes)),
+		},
+		bindings: make([]SchemaBinding, len(m.bindings)),
+	}
+	copy(res.path.Nodes, m.path.Nodes)
+	copy(res.bindings, m.bindings)
+	return res
+}
+
+// MutatorForAssignMetadata returns an AssignMetadataMutator built from
+// the given assignMetadata instance.
+func MutatorForAssignMetadata(assignMetadata *mutationsv1alpha1.AssignMetadata) (*AssignMetadataMutator, error) {
+	id, err := MakeID(assignMetadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to retrieve id for assignMetadata type")
+	}
+
+	path, err := parser.Parse(assignMetadata.Spec.Location)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse the location specified")
+	}
+
+	if _, _, _, err := metadataLocationParts(path); err != nil {
+		return nil, err
+	}
+
+	if _, err := stringValue(assignMetadata); err != nil {
+		return nil, err
+	}
+
+	return &AssignMetadataMutator{
+		id:             id,
+		assignMetadata: assignMetadata.DeepCopy(),
+		bindings:       applyToToBindings(assignMetadata.Spec.ApplyTo),
+		path:           path,
+	}, nil
+}
+
+// IsValidAssignMetadata returns an error if the given assignMetadata
+// object is not semantically valid.
+func IsValidAssignMetadata(assignMetadata *mutationsv1alpha1.AssignMetadata) error {
+	path, err := parser.Parse(assignMetadata.Spec.Location)
+	if err != nil {
+		return errors.Wrap(err, "invalid location format")
+	}
+
+	if _, _, _, err := metadataLocationParts(path); err != nil {
+		return err
+	}
+
+	_, err = stringValue(assignMetadata)
+	return err
+}
+
+// stringValue returns assignMetadata's parameters.assign.value as a
+// string, or an error if it is absent or of any other type. Labels and
+// annotations are always strings, so this is rejected once here at
+// load/admission time rather than on every admission request the
+// mutator matches.
+func stringValue(assignMetadata *mutationsv1alpha1.AssignMetadata) (string, error) {
+	value, err := unmarshalValue(assignMetadata.Spec.Parameters.Assign.Raw)
+	if err != nil {
+		return "", err
+	}
+	strValue, ok := value.(string)
+	if !ok {
+		return "", errors.New("assignMetadata value must be a string")
+	}
+	return strValue, nil
+}
+
+// metadataLocationParts validates that path is exactly
+// metadata.labels.<key> or metadata.annotations.<key>, and returns its
+// three components.
+func metadataLocationParts(path *parser.Path) (root, section, key string, err error) {
+	if len(path.Nodes) != 3 {
+		return "", "", "", errors.New("assignMetadata location must be of the form metadata.labels.<key> or metadata.annotations.<key>")
+	}
+
+	fields := make([]string, len(path.Nodes))
+	for i, node := range path.Nodes {
+		obj, ok := node.(*parser.Object)
+		if !ok {
+			return "", "", "", errors.New("assignMetadata location can't contain a list")
+		}
+		fields[i] = obj.Reference
+	}
+
+	if fields[0] != "metadata" || (fields[1] != "labels" && fields[1] != "annotations") {
+		return "", "", "", errors.New("assignMetadata location must be of the form metadata.labels.<key> or metadata.annotations.<key>")
+	}
+
+	return fields[0], fields[1], fields[2], nil
+}
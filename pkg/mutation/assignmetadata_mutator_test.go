@@ -0,0 +1,82 @@
+package mutation
+
+import (
+	"testing"
+
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+)
+
+func TestMetadataLocationParts(t *testing.T) {
+	tcs := []struct {
+		name    string
+		path    *parser.Path
+		wantErr bool
+	}{
+		{
+			name: "valid label location",
+			path: &parser.Path{Nodes: []parser.Node{
+				&parser.Object{Reference: "metadata"},
+				&parser.Object{Reference: "labels"},
+				&parser.Object{Reference: "owner"},
+			}},
+		},
+		{
+			name: "valid annotation location",
+			path: &parser.Path{Nodes: []parser.Node{
+				&parser.Object{Reference: "metadata"},
+				&parser.Object{Reference: "annotations"},
+				&parser.Object{Reference: "owner"},
+			}},
+		},
+		{
+			name: "wrong root",
+			path: &parser.Path{Nodes: []parser.Node{
+				&parser.Object{Reference: "spec"},
+				&parser.Object{Reference: "labels"},
+				&parser.Object{Reference: "owner"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "wrong section",
+			path: &parser.Path{Nodes: []parser.Node{
+				&parser.Object{Reference: "metadata"},
+				&parser.Object{Reference: "name"},
+				&parser.Object{Reference: "owner"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "too short",
+			path: &parser.Path{Nodes: []parser.Node{
+				&parser.Object{Reference: "metadata"},
+				&parser.Object{Reference: "labels"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "too long",
+			path: &parser.Path{Nodes: []parser.Node{
+				&parser.Object{Reference: "metadata"},
+				&parser.Object{Reference: "labels"},
+				&parser.Object{Reference: "owner"},
+				&parser.Object{Reference: "extra"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			root, section, key, err := metadataLocationParts(tc.path)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got err %v, wantErr %v", err, tc.wantErr)
+			}
+			if err == nil {
+				if root != "metadata" || key != "owner" {
+					t.Errorf("got root=%q section=%q key=%q", root, section, key)
+				}
+			}
+		})
+	}
+}
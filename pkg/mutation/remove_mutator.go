@@ -0,0 +1,208 @@
+package mutation
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RemoveMutator is a mutator object built out of a
+// Remove instance.
+type RemoveMutator struct {
+	id       ID
+	remove   *mutationsv1alpha1.Remove
+	path     *parser.Path
+	bindings []SchemaBinding
+}
+
+// RemoveMutator implements mutatorWithSchema
+var _ MutatorWithSchema = &RemoveMutator{}
+
+func (m *RemoveMutator) Matches(obj runtime.Object, ns *corev1.Namespace) bool {
+	matches, err := Matches(m.remove.Spec.Match, obj, ns)
+	if err != nil {
+		log.Error(err, "RemoveMutator.Matches failed", "remove", m.remove.Name)
+		return false
+	}
+	return matches
+}
+
+// Mutate deletes the field or list entry addressed by the mutator's path.
+// Remove has no value to write, so unlike AssignMutator it does not go
+// through the generic Mutate dispatch and instead walks the path itself.
+func (m *RemoveMutator) Mutate(obj *unstructured.Unstructured) error {
+	return removeAtPath(obj.Object, m.path.Nodes)
+}
+
+func (m *RemoveMutator) ID() ID {
+	return m.id
+}
+
+func (m *RemoveMutator) SchemaBindings() []SchemaBinding {
+	return m.bindings
+}
+
+// Value always returns nil: a remove has nothing to assign.
+func (m *RemoveMutator) Value() (interface{}, error) {
+	return nil, nil
+}
+
+func (m *RemoveMutator) HasDiff(mutator Mutator) bool {
+	toCheck, ok := mutator.(*RemoveMutator)
+	if !ok { // different types, different
+		return true
+	}
+
+	if !cmp.Equal(toCheck.id, m.id) {
+		return true
+	}
+	if !cmp.Equal(toCheck.path, m.path) {
+		return true
+	}
+	if !cmp.Equal(toCheck.bindings, m.bindings) {
+		return true
+	}
+
+	// any difference in spec may be enough
+	if !cmp.Equal(toCheck.remove.Spec, m.remove.Spec) {
+		return true
+	}
+
+	return false
+}
+
+func (m *RemoveMutator) Path() *parser.Path {
+	return m.path
+}
+
+func (m *RemoveMutator) DeepCopy() Mutator {
+	res := &RemoveMutator{
+		id:     m.id,
+		remove: m.remove.DeepCopy(),
+		path: &parser.Path{
+			Nodes: make([]parser.Node, len(m.path.Nodes)),
+		},
+		bindings: make([]SchemaBinding, len(m.bindings)),
+	}
+	copy(res.path.Nodes, m.path.Nodes)
+	copy(res.bindings, m.bindings)
+	return res
+}
+
+// MutatorForRemove returns a RemoveMutator built from
+// the given remove instance.
+func MutatorForRemove(remove *mutationsv1alpha1.Remove) (*RemoveMutator, error) {
+	id, err := MakeID(remove)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to retrieve id for remove type")
+	}
+
+	path, err := parser.Parse(remove.Spec.Location)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse the location specified")
+	}
+
+	return &RemoveMutator{
+		id:       id,
+		remove:   remove.DeepCopy(),
+		bindings: applyToToBindings(remove.Spec.ApplyTo),
+		path:     path,
+	}, nil
+}
+
+// IsValidRemove returns an error if the given remove object is not
+// semantically valid
+func IsValidRemove(remove *mutationsv1alpha1.Remove) error {
+	path, err := parser.Parse(remove.Spec.Location)
+	if err != nil {
+		return errors.Wrap(err, "invalid location format")
+	}
+
+	if hasMetadataRoot(path) {
+		return errors.New("remove can't change metadata")
+	}
+
+	return nil
+}
+
+// removeAtPath deletes the field described by nodes from current. Every
+// node but the last is walked to find the parent container; the last node
+// names what gets deleted. List nodes delete only the element whose
+// KeyField matches KeyValue, rather than the whole list.
+func removeAtPath(current map[string]interface{}, nodes []parser.Node) error {
+	if len(nodes) == 0 {
+		return errors.New("empty path")
+	}
+
+	field, ok := nodes[0].(*parser.Object)
+	if !ok {
+		return errors.New("invalid path format: path must start with a field reference")
+	}
+
+	if len(nodes) == 1 {
+		delete(current, field.Reference)
+		return nil
+	}
+
+	if listNode, ok := nodes[1].(*parser.List); ok {
+		list, found, err := unstructured.NestedSlice(current, field.Reference)
+		if err != nil || !found {
+			return err
+		}
+		newList, err := removeFromList(list, listNode, nodes[2:])
+		if err != nil {
+			return err
+		}
+		return unstructured.SetNestedSlice(current, newList, field.Reference)
+	}
+
+	child, found, err := unstructured.NestedMap(current, field.Reference)
+	if err != nil || !found {
+		return err
+	}
+	if err := removeAtPath(child, nodes[1:]); err != nil {
+		return err
+	}
+	return unstructured.SetNestedMap(current, child, field.Reference)
+}
+
+// removeFromList applies a removal to the elements of list that match
+// listNode's key. When rest is empty the matching element itself is
+// dropped from the list; otherwise the removal continues inside it.
+func removeFromList(list []interface{}, listNode *parser.List, rest []parser.Node) ([]interface{}, error) {
+	if listNode.KeyValue == nil {
+		return nil, errors.New("can't remove from a globbed list")
+	}
+
+	if len(rest) == 0 {
+		result := make([]interface{}, 0, len(list))
+		for _, item := range list {
+			entry, ok := item.(map[string]interface{})
+			if ok && fmt.Sprintf("%v", entry[listNode.KeyField]) == *listNode.KeyValue {
+				continue
+			}
+			result = append(result, item)
+		}
+		return result, nil
+	}
+
+	result := make([]interface{}, len(list))
+	for i, item := range list {
+		entry, ok := item.(map[string]interface{})
+		if !ok || fmt.Sprintf("%v", entry[listNode.KeyField]) != *listNode.KeyValue {
+			result[i] = item
+			continue
+		}
+		if err := removeAtPath(entry, rest); err != nil {
+			return nil, err
+		}
+		result[i] = entry
+	}
+	return result, nil
+}
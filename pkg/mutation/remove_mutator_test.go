@@ -0,0 +1,147 @@
+package mutation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+)
+
+func TestRemoveAtPath_TopLevelField(t *testing.T) {
+	obj := map[string]interface{}{"foo": "bar", "baz": "qux"}
+
+	if err := removeAtPath(obj, []parser.Node{&parser.Object{Reference: "foo"}}); err != nil {
+		t.Fatalf("removeAtPath returned error: %v", err)
+	}
+
+	want := map[string]interface{}{"baz": "qux"}
+	if diff := cmp.Diff(want, obj); diff != "" {
+		t.Errorf("unexpected object after remove (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemoveAtPath_NestedField(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"securityContext": map[string]interface{}{
+				"privileged": true,
+				"runAsUser":  int64(1000),
+			},
+		},
+	}
+
+	nodes := []parser.Node{
+		&parser.Object{Reference: "spec"},
+		&parser.Object{Reference: "securityContext"},
+		&parser.Object{Reference: "privileged"},
+	}
+	if err := removeAtPath(obj, nodes); err != nil {
+		t.Fatalf("removeAtPath returned error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"securityContext": map[string]interface{}{
+				"runAsUser": int64(1000),
+			},
+		},
+	}
+	if diff := cmp.Diff(want, obj); diff != "" {
+		t.Errorf("unexpected object after remove (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemoveAtPath_ListEntry(t *testing.T) {
+	keyValue := "a"
+	obj := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "a", "image": "img-a"},
+			map[string]interface{}{"name": "b", "image": "img-b"},
+		},
+	}
+
+	nodes := []parser.Node{
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", KeyValue: &keyValue},
+	}
+	if err := removeAtPath(obj, nodes); err != nil {
+		t.Fatalf("removeAtPath returned error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "b", "image": "img-b"},
+		},
+	}
+	if diff := cmp.Diff(want, obj); diff != "" {
+		t.Errorf("unexpected object after remove (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemoveAtPath_FieldInsideListEntry(t *testing.T) {
+	keyValue := "a"
+	obj := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name":            "a",
+				"securityContext": map[string]interface{}{"privileged": true},
+			},
+			map[string]interface{}{
+				"name":            "b",
+				"securityContext": map[string]interface{}{"privileged": true},
+			},
+		},
+	}
+
+	nodes := []parser.Node{
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", KeyValue: &keyValue},
+		&parser.Object{Reference: "securityContext"},
+		&parser.Object{Reference: "privileged"},
+	}
+	if err := removeAtPath(obj, nodes); err != nil {
+		t.Fatalf("removeAtPath returned error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name":            "a",
+				"securityContext": map[string]interface{}{},
+			},
+			map[string]interface{}{
+				"name":            "b",
+				"securityContext": map[string]interface{}{"privileged": true},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, obj); diff != "" {
+		t.Errorf("unexpected object after remove (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemoveFromList_GlobIsRejected(t *testing.T) {
+	list := []interface{}{map[string]interface{}{"name": "a"}}
+	listNode := &parser.List{KeyField: "name", Glob: true}
+
+	if _, err := removeFromList(list, listNode, nil); err == nil {
+		t.Error("expected an error removing from a globbed list, got nil")
+	}
+}
+
+func TestRemoveAtPath_MissingFieldIsANoop(t *testing.T) {
+	obj := map[string]interface{}{"foo": "bar"}
+
+	err := removeAtPath(obj, []parser.Node{
+		&parser.Object{Reference: "spec"},
+		&parser.Object{Reference: "missing"},
+	})
+	if err != nil {
+		t.Fatalf("removeAtPath returned error: %v", err)
+	}
+
+	want := map[string]interface{}{"foo": "bar"}
+	if diff := cmp.Diff(want, obj); diff != "" {
+		t.Errorf("object should be unchanged (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,170 @@
+package mutation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	openapiproto "k8s.io/kube-openapi/pkg/util/proto"
+	"k8s.io/kubectl/pkg/util/openapi"
+)
+
+// SchemaValidator checks that the value an Assign mutator would write is
+// compatible with the OpenAPI schema of the resources it targets. It is
+// injectable so tests can supply a fake openapi.Resources instead of
+// talking to a live API server.
+type SchemaValidator interface {
+	// ValidateAssign returns an error if value is not a valid value for
+	// path on any of the GVKs described by bindings.
+	ValidateAssign(bindings []SchemaBinding, path *parser.Path, value interface{}) error
+}
+
+// openAPISchemaValidator is the production SchemaValidator, backed by the
+// OpenAPI schema resources published by the API server.
+type openAPISchemaValidator struct {
+	resources openapi.Resources
+}
+
+// NewSchemaValidator returns a SchemaValidator backed by the given OpenAPI
+// resources.
+func NewSchemaValidator(resources openapi.Resources) SchemaValidator {
+	return &openAPISchemaValidator{resources: resources}
+}
+
+func (v *openAPISchemaValidator) ValidateAssign(bindings []SchemaBinding, path *parser.Path, value interface{}) error {
+	if v.resources == nil {
+		return nil
+	}
+
+	for _, binding := range bindings {
+		for _, gvk := range bindingGVKs(binding) {
+			s := v.resources.LookupResource(gvk)
+			if s == nil {
+				continue
+			}
+			if err := validatePathAgainstSchema(s, path.Nodes, value); err != nil {
+				return errors.Wrapf(err, "assign value invalid for %s", gvk)
+			}
+		}
+	}
+	return nil
+}
+
+// bindingGVKs expands a SchemaBinding's Groups/Versions/Kinds into the
+// concrete GroupVersionKinds it matches.
+func bindingGVKs(binding SchemaBinding) []schema.GroupVersionKind {
+	groups := binding.Groups
+	if len(groups) == 0 {
+		groups = []string{""}
+	}
+
+	var gvks []schema.GroupVersionKind
+	for _, g := range groups {
+		for _, v := range binding.Versions {
+			for _, k := range binding.Kinds {
+				gvks = append(gvks, schema.GroupVersionKind{Group: g, Version: v, Kind: k})
+			}
+		}
+	}
+	return gvks
+}
+
+// validatePathAgainstSchema walks nodes through s, verifying that each
+// field or list the path references exists in the schema. At the
+// terminal node it checks value against the schema found there.
+func validatePathAgainstSchema(s openapiproto.Schema, nodes []parser.Node, value interface{}) error {
+	if len(nodes) == 0 {
+		return validateValueAgainstSchema(s, value)
+	}
+
+	switch node := nodes[0].(type) {
+	case *parser.Object:
+		switch schemaNode := s.(type) {
+		case *openapiproto.Kind:
+			child, ok := schemaNode.Fields[node.Reference]
+			if !ok {
+				return fmt.Errorf("field %q not found in schema", node.Reference)
+			}
+			return validatePathAgainstSchema(child, nodes[1:], value)
+		case *openapiproto.Map:
+			// a Map schema allows arbitrary keys, e.g. labels/annotations
+			return validatePathAgainstSchema(schemaNode.SubType, nodes[1:], value)
+		default:
+			return fmt.Errorf("path references field %q but schema is not an object", node.Reference)
+		}
+	case *parser.List:
+		array, ok := s.(*openapiproto.Array)
+		if !ok {
+			return errors.New("path indexes into a list but schema is not an array")
+		}
+		return validatePathAgainstSchema(array.SubType, nodes[1:], value)
+	default:
+		return fmt.Errorf("unrecognized path node type %T", node)
+	}
+}
+
+// validateValueAgainstSchema checks that value's JSON type, and for
+// numbers its format, matches the terminal schema node. When a whole
+// object is assigned it also checks that every field the schema marks
+// required is present.
+func validateValueAgainstSchema(s openapiproto.Schema, value interface{}) error {
+	switch schemaNode := s.(type) {
+	case *openapiproto.Primitive:
+		return validatePrimitive(schemaNode, value)
+	case *openapiproto.Kind:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected object, got %T", value)
+		}
+		for _, required := range schemaNode.RequiredFields {
+			if _, ok := obj[required]; !ok {
+				return fmt.Errorf("missing required field %q", required)
+			}
+		}
+		return nil
+	case *openapiproto.Array:
+		if _, ok := value.([]interface{}); !ok {
+			return fmt.Errorf("expected array, got %T", value)
+		}
+		return nil
+	case *openapiproto.Map:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Errorf("expected object, got %T", value)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func validatePrimitive(p *openapiproto.Primitive, value interface{}) error {
+	switch p.Type {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected boolean, got %T", value)
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("expected integer, got %v", value)
+		}
+		if p.Format == "int32" && (n < math.MinInt32 || n > math.MaxInt32) {
+			return fmt.Errorf("expected int32, got %v", value)
+		}
+	case "number":
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("expected number, got %T", value)
+		}
+		if p.Format == "float" && math.Abs(n) > math.MaxFloat32 {
+			return fmt.Errorf("expected float, got %v", value)
+		}
+	}
+	return nil
+}
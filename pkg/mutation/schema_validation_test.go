@@ -0,0 +1,107 @@
+package mutation
+
+import (
+	"testing"
+
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+	openapiproto "k8s.io/kube-openapi/pkg/util/proto"
+)
+
+func podSpecSchema() *openapiproto.Kind {
+	return &openapiproto.Kind{
+		Fields: map[string]openapiproto.Schema{
+			"spec": &openapiproto.Kind{
+				Fields: map[string]openapiproto.Schema{
+					"replicas": &openapiproto.Primitive{Type: "integer"},
+					"containers": &openapiproto.Array{
+						SubType: &openapiproto.Kind{
+							Fields: map[string]openapiproto.Schema{
+								"name":  &openapiproto.Primitive{Type: "string"},
+								"image": &openapiproto.Primitive{Type: "string"},
+							},
+							RequiredFields: []string{"name"},
+						},
+					},
+				},
+			},
+			"metadata": &openapiproto.Kind{
+				Fields: map[string]openapiproto.Schema{
+					"labels": &openapiproto.Map{SubType: &openapiproto.Primitive{Type: "string"}},
+				},
+			},
+		},
+	}
+}
+
+func TestValidatePathAgainstSchema_Primitive(t *testing.T) {
+	nodes := []parser.Node{
+		&parser.Object{Reference: "spec"},
+		&parser.Object{Reference: "replicas"},
+	}
+
+	if err := validatePathAgainstSchema(podSpecSchema(), nodes, float64(3)); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := validatePathAgainstSchema(podSpecSchema(), nodes, "three"); err == nil {
+		t.Error("expected a type mismatch error, got nil")
+	}
+}
+
+func TestValidatePathAgainstSchema_UnknownField(t *testing.T) {
+	nodes := []parser.Node{
+		&parser.Object{Reference: "spec"},
+		&parser.Object{Reference: "doesNotExist"},
+	}
+
+	if err := validatePathAgainstSchema(podSpecSchema(), nodes, "whatever"); err == nil {
+		t.Error("expected an error for an unknown field, got nil")
+	}
+}
+
+func TestValidatePathAgainstSchema_MapAllowsArbitraryKeys(t *testing.T) {
+	nodes := []parser.Node{
+		&parser.Object{Reference: "metadata"},
+		&parser.Object{Reference: "labels"},
+		&parser.Object{Reference: "any-key-at-all"},
+	}
+
+	if err := validatePathAgainstSchema(podSpecSchema(), nodes, "a-label-value"); err != nil {
+		t.Errorf("expected no error for an arbitrary map key, got %v", err)
+	}
+}
+
+func TestValidatePathAgainstSchema_ListElementField(t *testing.T) {
+	keyValue := "nginx"
+	nodes := []parser.Node{
+		&parser.Object{Reference: "spec"},
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", KeyValue: &keyValue},
+		&parser.Object{Reference: "image"},
+	}
+
+	if err := validatePathAgainstSchema(podSpecSchema(), nodes, "nginx:latest"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := validatePathAgainstSchema(podSpecSchema(), nodes, 123.0); err == nil {
+		t.Error("expected a type mismatch error assigning a number to a string field, got nil")
+	}
+}
+
+func TestValidatePathAgainstSchema_WholeObjectAssignedToList(t *testing.T) {
+	keyValue := "nginx"
+	nodes := []parser.Node{
+		&parser.Object{Reference: "spec"},
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", KeyValue: &keyValue},
+	}
+
+	ok := map[string]interface{}{"name": "nginx", "image": "nginx:latest"}
+	if err := validatePathAgainstSchema(podSpecSchema(), nodes, ok); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	missingRequired := map[string]interface{}{"image": "nginx:latest"}
+	if err := validatePathAgainstSchema(podSpecSchema(), nodes, missingRequired); err == nil {
+		t.Error("expected an error for a missing required field, got nil")
+	}
+}
@@ -0,0 +1,141 @@
+package mutation
+
+import (
+	"encoding/json"
+
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/kubectl/pkg/util/openapi"
+)
+
+// PatchMetaSource resolves the strategic-merge-patch merge-key metadata
+// for a GVK. Built-in types with a known OpenAPI schema have one; CRDs do
+// not, and AssignMutator.Mutate falls back to the path-walking backend for
+// those.
+type PatchMetaSource interface {
+	LookupPatchMeta(gvk schema.GroupVersionKind) (strategicpatch.LookupPatchMeta, bool)
+}
+
+// openAPIPatchMetaSource is the production PatchMetaSource, backed by the
+// OpenAPI schema resources published by the API server.
+type openAPIPatchMetaSource struct {
+	resources openapi.Resources
+}
+
+// NewPatchMetaSource returns a PatchMetaSource backed by the given
+// OpenAPI resources.
+func NewPatchMetaSource(resources openapi.Resources) PatchMetaSource {
+	return &openAPIPatchMetaSource{resources: resources}
+}
+
+func (s *openAPIPatchMetaSource) LookupPatchMeta(gvk schema.GroupVersionKind) (strategicpatch.LookupPatchMeta, bool) {
+	if s.resources == nil {
+		return nil, false
+	}
+	gvkSchema := s.resources.LookupResource(gvk)
+	if gvkSchema == nil {
+		return nil, false
+	}
+	return strategicpatch.NewPatchMetaFromOpenAPI(gvkSchema), true
+}
+
+// mutateWithStrategicMergePatch applies m's assign spec to obj as a
+// strategic merge patch instead of walking m.path by hand, so that merge
+// keys (e.g. "name" on containers, "containerPort"+"protocol" on ports)
+// are respected instead of producing a positional list write.
+func mutateWithStrategicMergePatch(m *AssignMutator, obj *unstructured.Unstructured, lookup strategicpatch.LookupPatchMeta) error {
+	value, err := m.Value()
+	if err != nil {
+		return err
+	}
+
+	doc, err := buildMergePatchDocument(m.path.Nodes, value)
+	if err != nil {
+		return errors.Wrap(err, "failed to build strategic merge patch")
+	}
+	patch, ok := doc.(map[string]interface{})
+	if !ok {
+		return errors.New("assign location must start with a field reference")
+	}
+
+	originalJSON, err := json.Marshal(obj.Object)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal original object")
+	}
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal strategic merge patch")
+	}
+
+	mergedJSON, err := strategicpatch.StrategicMergePatchUsingLookupPatchMeta(originalJSON, patchJSON, lookup)
+	if err != nil {
+		return errors.Wrap(err, "failed to apply strategic merge patch")
+	}
+
+	merged := map[string]interface{}{}
+	if err := json.Unmarshal(mergedJSON, &merged); err != nil {
+		return errors.Wrap(err, "failed to unmarshal merged object")
+	}
+	obj.Object = merged
+	return nil
+}
+
+// buildMergePatchDocument turns a parser.Path and the value to assign at
+// its end into the nested structure a strategic merge patch expects: an
+// object node nests a key, a list node becomes a single-element array
+// carrying its merge key so the patch targets that element instead of
+// replacing the whole list. It errors out on a globbed list node: a glob
+// has no single KeyValue to merge on, so the caller must fall back to the
+// path-walking backend for those instead of calling this at all.
+func buildMergePatchDocument(nodes []parser.Node, value interface{}) (interface{}, error) {
+	if len(nodes) == 0 {
+		return value, nil
+	}
+
+	switch node := nodes[0].(type) {
+	case *parser.Object:
+		child, err := buildMergePatchDocument(nodes[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			node.Reference: child,
+		}, nil
+	case *parser.List:
+		if node.Glob || node.KeyValue == nil {
+			return nil, errors.New("can't build a strategic merge patch for a globbed list")
+		}
+		if len(nodes) == 1 {
+			return []interface{}{value}, nil
+		}
+		item := map[string]interface{}{node.KeyField: *node.KeyValue}
+		rest, err := buildMergePatchDocument(nodes[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		if restMap, ok := rest.(map[string]interface{}); ok {
+			for k, v := range restMap {
+				item[k] = v
+			}
+		}
+		return []interface{}{item}, nil
+	default:
+		return value, nil
+	}
+}
+
+// pathHasGlob reports whether any list node in nodes is a globbed match
+// (e.g. containers[name: *]). Such a path has no single element to
+// target, so it can't be expressed as a strategic merge patch and must
+// use the path-walking backend instead.
+func pathHasGlob(nodes []parser.Node) bool {
+	for _, node := range nodes {
+		if list, ok := node.(*parser.List); ok && list.Glob {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package mutation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation/path/parser"
+)
+
+func TestBuildMergePatchDocument_Field(t *testing.T) {
+	nodes := []parser.Node{
+		&parser.Object{Reference: "spec"},
+		&parser.Object{Reference: "replicas"},
+	}
+
+	got, err := buildMergePatchDocument(nodes, float64(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": float64(3),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected patch document (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildMergePatchDocument_ListElementField(t *testing.T) {
+	keyValue := "nginx"
+	nodes := []parser.Node{
+		&parser.Object{Reference: "spec"},
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", KeyValue: &keyValue},
+		&parser.Object{Reference: "image"},
+	}
+
+	got, err := buildMergePatchDocument(nodes, "nginx:latest")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx:latest"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected patch document (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildMergePatchDocument_WholeListElement(t *testing.T) {
+	keyValue := "nginx"
+	nodes := []parser.Node{
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", KeyValue: &keyValue},
+	}
+	value := map[string]interface{}{"name": "nginx", "image": "nginx:latest"}
+
+	got, err := buildMergePatchDocument(nodes, value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"containers": []interface{}{value},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected patch document (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildMergePatchDocument_GlobIsRejected(t *testing.T) {
+	nodes := []parser.Node{
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", Glob: true},
+		&parser.Object{Reference: "image"},
+	}
+
+	if _, err := buildMergePatchDocument(nodes, "nginx:latest"); err == nil {
+		t.Error("expected an error building a patch for a globbed list, got nil")
+	}
+}
+
+func TestPathHasGlob(t *testing.T) {
+	globKey := "*"
+	_ = globKey
+
+	nonGlob := []parser.Node{
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", KeyValue: &globKey},
+	}
+	if pathHasGlob(nonGlob) {
+		t.Error("expected pathHasGlob to be false for a keyed list node")
+	}
+
+	glob := []parser.Node{
+		&parser.Object{Reference: "containers"},
+		&parser.List{KeyField: "name", Glob: true},
+	}
+	if !pathHasGlob(glob) {
+		t.Error("expected pathHasGlob to be true for a globbed list node")
+	}
+}
@@ -0,0 +1,139 @@
+package mutation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// systemMutator is the common surface System needs from any mutator kind
+// it dispatches: report its ID (for ordering and dedup on Add), whether
+// it applies to obj, and write to obj.
+type systemMutator interface {
+	ID() ID
+	Matches(obj runtime.Object, ns *corev1.Namespace) bool
+	Mutate(obj *unstructured.Unstructured) error
+}
+
+// System holds every mutator known to the controller and applies them to
+// admitted objects in a deterministic order: Remove mutators run first so
+// stale fields are gone before anything else assigns new ones, then
+// Assign mutators, then AssignMetadata mutators so they see the
+// fully-assigned object. Within each kind, mutators are ordered by ID so
+// the same set of mutators always applies in the same order regardless of
+// registration order.
+type System struct {
+	removeMutators         []*RemoveMutator
+	assignMutators         []*AssignMutator
+	assignMetadataMutators []*AssignMetadataMutator
+}
+
+// NewSystem returns an empty mutation System.
+func NewSystem() *System {
+	return &System{}
+}
+
+// AddRemoveMutator adds m, replacing any existing mutator with the same
+// ID.
+func (s *System) AddRemoveMutator(m *RemoveMutator) {
+	for i, existing := range s.removeMutators {
+		if cmp.Equal(existing.ID(), m.ID()) {
+			s.removeMutators[i] = m
+			return
+		}
+	}
+	s.removeMutators = append(s.removeMutators, m)
+}
+
+// AddAssignMutator adds m, replacing any existing mutator with the same
+// ID.
+func (s *System) AddAssignMutator(m *AssignMutator) {
+	for i, existing := range s.assignMutators {
+		if cmp.Equal(existing.ID(), m.ID()) {
+			s.assignMutators[i] = m
+			return
+		}
+	}
+	s.assignMutators = append(s.assignMutators, m)
+}
+
+// AddAssignMetadataMutator adds m, replacing any existing mutator with
+// the same ID.
+func (s *System) AddAssignMetadataMutator(m *AssignMetadataMutator) {
+	for i, existing := range s.assignMetadataMutators {
+		if cmp.Equal(existing.ID(), m.ID()) {
+			s.assignMetadataMutators[i] = m
+			return
+		}
+	}
+	s.assignMetadataMutators = append(s.assignMetadataMutators, m)
+}
+
+// Mutate runs every mutator that matches obj against it, in the order
+// documented on System, and reports whether obj actually came out
+// different from how it went in. A mutator that matches and returns
+// without error does not by itself count: AssignMetadata in particular is
+// create-only and commonly matches an object it ends up leaving alone.
+func (s *System) Mutate(obj *unstructured.Unstructured, ns *corev1.Namespace) (bool, error) {
+	var ordered []systemMutator
+	ordered = append(ordered, sortedRemoveMutators(s.removeMutators)...)
+	ordered = append(ordered, sortedAssignMutators(s.assignMutators)...)
+	ordered = append(ordered, sortedAssignMetadataMutators(s.assignMetadataMutators)...)
+
+	mutated := false
+	for _, m := range ordered {
+		if !m.Matches(obj, ns) {
+			continue
+		}
+
+		before := obj.DeepCopy()
+		if err := m.Mutate(obj); err != nil {
+			return mutated, err
+		}
+		if !cmp.Equal(before.Object, obj.Object) {
+			mutated = true
+		}
+	}
+
+	return mutated, nil
+}
+
+func sortedRemoveMutators(mutators []*RemoveMutator) []systemMutator {
+	cp := make([]*RemoveMutator, len(mutators))
+	copy(cp, mutators)
+	sort.Slice(cp, func(i, j int) bool { return fmt.Sprint(cp[i].ID()) < fmt.Sprint(cp[j].ID()) })
+
+	res := make([]systemMutator, len(cp))
+	for i, m := range cp {
+		res[i] = m
+	}
+	return res
+}
+
+func sortedAssignMutators(mutators []*AssignMutator) []systemMutator {
+	cp := make([]*AssignMutator, len(mutators))
+	copy(cp, mutators)
+	sort.Slice(cp, func(i, j int) bool { return fmt.Sprint(cp[i].ID()) < fmt.Sprint(cp[j].ID()) })
+
+	res := make([]systemMutator, len(cp))
+	for i, m := range cp {
+		res[i] = m
+	}
+	return res
+}
+
+func sortedAssignMetadataMutators(mutators []*AssignMetadataMutator) []systemMutator {
+	cp := make([]*AssignMetadataMutator, len(mutators))
+	copy(cp, mutators)
+	sort.Slice(cp, func(i, j int) bool { return fmt.Sprint(cp[i].ID()) < fmt.Sprint(cp[j].ID()) })
+
+	res := make([]systemMutator, len(cp))
+	for i, m := range cp {
+		res[i] = m
+	}
+	return res
+}